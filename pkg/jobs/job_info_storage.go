@@ -89,6 +89,22 @@ func (i InfoStorage) get(ctx context.Context, infoKey []byte) ([]byte, bool, err
 	return []byte(*value), true, nil
 }
 
+// infoBatchSize is the default number of info rows operated on per SQL
+// statement by the batched entry points, matching the batch size used by
+// other CockroachDB KV wrappers to stay well under statement size limits.
+// Callers can override it per call by passing a positive batchSize to the
+// Batch* methods; batchSize <= 0 falls back to this default.
+const infoBatchSize = 128
+
+// resolveBatchSize returns batchSize if positive, else the default
+// infoBatchSize.
+func resolveBatchSize(batchSize int) int {
+	if batchSize <= 0 {
+		return infoBatchSize
+	}
+	return batchSize
+}
+
 func (i InfoStorage) write(ctx context.Context, infoKey, value []byte) error {
 	if i.txn == nil {
 		return errors.New("cannot write to the job info table without an associated txn")
@@ -97,14 +113,8 @@ func (i InfoStorage) write(ctx context.Context, infoKey, value []byte) error {
 	ctx, sp := tracing.ChildSpan(ctx, "write-job-info")
 	defer sp.Finish()
 
-	j := i.j
-
-	if j.Session() != nil {
-		if err := i.checkClaimSession(ctx); err != nil {
-			return err
-		}
-	} else {
-		log.VInfof(ctx, 1, "job %d: writing to the system.job_info with no session ID", j.ID())
+	if err := i.checkWriteClaimSession(ctx); err != nil {
+		return err
 	}
 
 	// First clear out any older revisions of this info.
@@ -112,7 +122,7 @@ func (i InfoStorage) write(ctx context.Context, infoKey, value []byte) error {
 		ctx, "write-job-info-delete", i.txn.KV(),
 		sessiondata.NodeUserSessionDataOverride,
 		"DELETE FROM system.job_info WHERE job_id = $1 AND info_key = $2",
-		j.ID(), infoKey,
+		i.j.ID(), infoKey,
 	)
 	if err != nil {
 		return err
@@ -123,11 +133,233 @@ func (i InfoStorage) write(ctx context.Context, infoKey, value []byte) error {
 		ctx, "write-job-info-insert", i.txn.KV(),
 		sessiondata.NodeUserSessionDataOverride,
 		`INSERT INTO system.job_info (job_id, info_key, written, value) VALUES ($1, $2, now(), $3)`,
-		j.ID(), infoKey, value,
+		i.j.ID(), infoKey, value,
 	)
 	return err
 }
 
+// checkWriteClaimSession verifies the claim session for the job, logging
+// instead of erroring when the job has no associated session.
+func (i InfoStorage) checkWriteClaimSession(ctx context.Context) error {
+	j := i.j
+	if j.Session() != nil {
+		if err := i.checkClaimSession(ctx); err != nil {
+			return err
+		}
+	} else {
+		log.VInfof(ctx, 1, "job %d: writing to the system.job_info with no session ID", j.ID())
+	}
+	return nil
+}
+
+// compareAndSwap atomically replaces the info record for infoKey with newValue,
+// but only if its current value matches expected (nil meaning the record
+// must not exist). It reads and locks the current revision with FOR UPDATE
+// so that two writers racing on the same key within the provided txn cannot
+// both observe a match, then performs the same delete-then-insert as write.
+// It returns swapped=false with no error on a mismatch, so callers can
+// distinguish a lost race from a real failure.
+func (i InfoStorage) compareAndSwap(
+	ctx context.Context, infoKey, expected, newValue []byte,
+) (swapped bool, retErr error) {
+	if i.txn == nil {
+		return false, errors.New("cannot access the job info table without an associated txn")
+	}
+
+	ctx, sp := tracing.ChildSpan(ctx, "compare-and-swap-job-info")
+	defer sp.Finish()
+
+	if err := i.checkWriteClaimSession(ctx); err != nil {
+		return false, err
+	}
+
+	row, err := i.txn.QueryRowEx(
+		ctx, "job-info-cas-read", i.txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT value FROM system.job_info WHERE job_id = $1 AND info_key = $2
+		ORDER BY written DESC LIMIT 1 FOR UPDATE`,
+		i.j.ID(), infoKey,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	var current []byte
+	exists := row != nil
+	if exists {
+		value, ok := row[0].(*tree.DBytes)
+		if !ok {
+			return false, errors.AssertionFailedf("job info: expected value to be DBytes (was %T)", row[0])
+		}
+		current = []byte(*value)
+	}
+
+	// expected == nil means the caller requires the key to be absent; track
+	// that via exists rather than inferring it from current, since a stored
+	// empty value and an absent record both have len(current) == 0.
+	if expected == nil {
+		if exists {
+			return false, nil
+		}
+	} else if !exists || !bytes.Equal(current, expected) {
+		return false, nil
+	}
+
+	if _, err := i.txn.ExecEx(
+		ctx, "job-info-cas-delete", i.txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		"DELETE FROM system.job_info WHERE job_id = $1 AND info_key = $2",
+		i.j.ID(), infoKey,
+	); err != nil {
+		return false, err
+	}
+
+	if _, err := i.txn.ExecEx(
+		ctx, "job-info-cas-insert", i.txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.job_info (job_id, info_key, written, value) VALUES ($1, $2, now(), $3)`,
+		i.j.ID(), infoKey, newValue,
+	); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// InfoEntry is a single key/value pair to be written by BatchWrite.
+type InfoEntry struct {
+	Key, Value []byte
+}
+
+// batchGet fetches the latest info record for each of the given keys, issuing
+// a single SELECT ... WHERE info_key = ANY($2) rather than one query per key.
+func (i InfoStorage) batchGet(
+	ctx context.Context, infoKeys [][]byte, batchSize int,
+) (map[string][]byte, error) {
+	if i.txn == nil {
+		return nil, errors.New("cannot access the job info table without an associated txn")
+	}
+
+	ctx, sp := tracing.ChildSpan(ctx, "batch-get-job-info")
+	defer sp.Finish()
+
+	batchSize = resolveBatchSize(batchSize)
+	result := make(map[string][]byte, len(infoKeys))
+	for start := 0; start < len(infoKeys); start += batchSize {
+		chunk := infoKeys[start:min(start+batchSize, len(infoKeys))]
+
+		rows, err := i.txn.QueryBufferedEx(
+			ctx, "job-info-batch-get", i.txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT DISTINCT ON (info_key) info_key, value FROM system.job_info
+			WHERE job_id = $1 AND info_key = ANY($2) ORDER BY info_key, written DESC`,
+			i.j.ID(), chunk,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			key, ok := row[0].(*tree.DBytes)
+			if !ok {
+				return nil, errors.AssertionFailedf("job info: expected info_key to be DBytes (was %T)", row[0])
+			}
+			value, ok := row[1].(*tree.DBytes)
+			if !ok {
+				return nil, errors.AssertionFailedf("job info: expected value to be DBytes (was %T)", row[1])
+			}
+			result[string(*key)] = []byte(*value)
+		}
+	}
+
+	return result, nil
+}
+
+// batchWrite writes the given entries under a single transaction, clearing
+// any older revisions with a single DELETE ... WHERE info_key = ANY($2) and
+// then writing the new values with a single multi-row INSERT, per batch.
+// system.job_info keeps multiple revisions per key ordered by written, so
+// this cannot be expressed as an INSERT ... ON CONFLICT upsert.
+func (i InfoStorage) batchWrite(ctx context.Context, entries []InfoEntry, batchSize int) error {
+	if i.txn == nil {
+		return errors.New("cannot write to the job info table without an associated txn")
+	}
+
+	ctx, sp := tracing.ChildSpan(ctx, "batch-write-job-info")
+	defer sp.Finish()
+
+	if err := i.checkWriteClaimSession(ctx); err != nil {
+		return err
+	}
+
+	batchSize = resolveBatchSize(batchSize)
+	for start := 0; start < len(entries); start += batchSize {
+		chunk := entries[start:min(start+batchSize, len(entries))]
+
+		keys := make([][]byte, len(chunk))
+		values := make([][]byte, len(chunk))
+		for idx, e := range chunk {
+			keys[idx] = e.Key
+			values[idx] = e.Value
+		}
+
+		// First clear out any older revisions of these keys.
+		if _, err := i.txn.ExecEx(
+			ctx, "job-info-batch-write-delete", i.txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			"DELETE FROM system.job_info WHERE job_id = $1 AND info_key = ANY($2)",
+			i.j.ID(), keys,
+		); err != nil {
+			return err
+		}
+
+		// Write the new info, using the same transaction.
+		if _, err := i.txn.ExecEx(
+			ctx, "job-info-batch-write-insert", i.txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`INSERT INTO system.job_info (job_id, info_key, written, value)
+			SELECT $1, key, now(), value FROM unnest($2::bytes[], $3::bytes[]) AS t(key, value)`,
+			i.j.ID(), keys, values,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// batchDelete removes all info records for the given keys under a single
+// transaction, issuing one DELETE ... WHERE info_key = ANY($2) per batch.
+func (i InfoStorage) batchDelete(ctx context.Context, infoKeys [][]byte, batchSize int) error {
+	if i.txn == nil {
+		return errors.New("cannot delete from the job info table without an associated txn")
+	}
+
+	ctx, sp := tracing.ChildSpan(ctx, "batch-delete-job-info")
+	defer sp.Finish()
+
+	if err := i.checkWriteClaimSession(ctx); err != nil {
+		return err
+	}
+
+	batchSize = resolveBatchSize(batchSize)
+	for start := 0; start < len(infoKeys); start += batchSize {
+		chunk := infoKeys[start:min(start+batchSize, len(infoKeys))]
+
+		_, err := i.txn.ExecEx(
+			ctx, "job-info-batch-delete", i.txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			"DELETE FROM system.job_info WHERE job_id = $1 AND info_key = ANY($2)",
+			i.j.ID(), chunk,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (i InfoStorage) iterate(
 	ctx context.Context, infoPrefix []byte, fn func(infoKey, value []byte) error,
 ) (retErr error) {
@@ -181,6 +413,117 @@ func (i InfoStorage) iterate(
 	return err
 }
 
+// iterateRange streams the latest-written revision of each info record whose
+// key falls in [start, end), in key order, up to limit keys, and returns the
+// first key it did not yield as a resumable cursor. A nil end means the range
+// is unbounded above.
+func (i InfoStorage) iterateRange(
+	ctx context.Context, start, end []byte, limit int, fn func(infoKey, value []byte) error,
+) (nextKey []byte, retErr error) {
+	if i.txn == nil {
+		return nil, errors.New("cannot iterate over the job info table without an associated txn")
+	}
+
+	// No SQL LIMIT here: a key can have multiple revisions, so limiting raw
+	// rows would undercount distinct keys. Instead we stream and stop once
+	// we've seen the (limit+1)th distinct key, in the loop below.
+	//
+	// A nil end means "unbounded above", but the executor encodes a typed
+	// nil []byte as an empty DBytes rather than SQL NULL, so an "$3 IS NULL"
+	// sentinel can't distinguish it from an empty upper bound. Branch on it
+	// in Go instead, and only include the upper bound predicate/arg when
+	// end is non-empty.
+	query := `SELECT info_key, value
+		FROM system.job_info
+		WHERE job_id = $1 AND info_key >= $2
+		ORDER BY info_key ASC, written DESC`
+	args := []interface{}{i.j.ID(), start}
+	if len(end) > 0 {
+		query = `SELECT info_key, value
+			FROM system.job_info
+			WHERE job_id = $1 AND info_key >= $2 AND info_key < $3
+			ORDER BY info_key ASC, written DESC`
+		args = append(args, end)
+	}
+
+	rows, err := i.txn.QueryIteratorEx(
+		ctx, "job-info-iter-range", i.txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		query, args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func(it isql.Rows) { retErr = errors.CombineErrors(retErr, it.Close()) }(rows)
+
+	var prevKey []byte
+	var yielded int
+	var ok bool
+	for ok, err = rows.Next(ctx); ok; ok, err = rows.Next(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		row := rows.Cur()
+
+		key, ok := row[0].(*tree.DBytes)
+		if !ok {
+			return nil, errors.AssertionFailedf("job info: expected info_key to be DBytes (was %T)", row[0])
+		}
+		infoKey := []byte(*key)
+
+		if bytes.Equal(infoKey, prevKey) {
+			continue
+		}
+		prevKey = append(prevKey[:0], infoKey...)
+
+		if yielded == limit {
+			return infoKey, nil
+		}
+
+		value, ok := row[1].(*tree.DBytes)
+		if !ok {
+			return nil, errors.AssertionFailedf("job info: expected value to be DBytes (was %T)", row[1])
+		}
+		if err = fn(infoKey, []byte(*value)); err != nil {
+			return nil, err
+		}
+		yielded++
+	}
+
+	return nil, err
+}
+
+// deleteRange removes all info records in [start, end). A nil end means the
+// range is unbounded above.
+func (i InfoStorage) deleteRange(ctx context.Context, start, end []byte) error {
+	if i.txn == nil {
+		return errors.New("cannot delete from the job info table without an associated txn")
+	}
+
+	ctx, sp := tracing.ChildSpan(ctx, "delete-range-job-info")
+	defer sp.Finish()
+
+	if err := i.checkWriteClaimSession(ctx); err != nil {
+		return err
+	}
+
+	// See the comment in iterateRange: a nil end can't be expressed as a SQL
+	// NULL sentinel here, so branch on it in Go instead.
+	query := "DELETE FROM system.job_info WHERE job_id = $1 AND info_key >= $2"
+	args := []interface{}{i.j.ID(), start}
+	if len(end) > 0 {
+		query = "DELETE FROM system.job_info WHERE job_id = $1 AND info_key >= $2 AND info_key < $3"
+		args = append(args, end)
+	}
+
+	_, err := i.txn.ExecEx(
+		ctx, "job-info-delete-range", i.txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		query, args...,
+	)
+	return err
+}
+
 // Get fetches the latest info record for the given job and infoKey.
 func (i InfoStorage) Get(ctx context.Context, infoKey []byte) ([]byte, bool, error) {
 	return i.get(ctx, infoKey)
@@ -194,6 +537,25 @@ func (i InfoStorage) Write(ctx context.Context, infoKey, value []byte) error {
 	return i.write(ctx, infoKey, value)
 }
 
+// CompareAndSwap atomically replaces the info record for infoKey with
+// newValue, but only if its current value equals expected (nil meaning the
+// record must not exist yet). On a mismatch it returns swapped=false with no
+// error, so two resumed job coordinators racing on the same key can tell a
+// lost race from a real failure instead of silently clobbering each other's
+// updates.
+func (i InfoStorage) CompareAndSwap(
+	ctx context.Context, infoKey, expected, newValue []byte,
+) (swapped bool, err error) {
+	return i.compareAndSwap(ctx, infoKey, expected, newValue)
+}
+
+// WriteIfAbsent writes value as the info record for infoKey only if no
+// record yet exists for that key, returning swapped=false with no error if
+// one already does.
+func (i InfoStorage) WriteIfAbsent(ctx context.Context, infoKey, value []byte) (swapped bool, err error) {
+	return i.compareAndSwap(ctx, infoKey, nil, value)
+}
+
 // Iterate iterates though the info records for a given job and info key prefix.
 func (i InfoStorage) Iterate(
 	ctx context.Context, infoPrefix []byte, fn func(infoKey, value []byte) error,
@@ -201,6 +563,52 @@ func (i InfoStorage) Iterate(
 	return i.iterate(ctx, infoPrefix, fn)
 }
 
+// IterateRange iterates, in key order, over the latest revision of each info
+// record whose key falls in [start, end), yielding at most limit records to
+// fn. A nil end means the range is unbounded above. It returns the first key
+// it did not yield as a resumable cursor so the caller can page through a
+// large key range in bounded-memory chunks; the returned key is nil once the
+// range is exhausted.
+func (i InfoStorage) IterateRange(
+	ctx context.Context, start, end []byte, limit int, fn func(infoKey, value []byte) error,
+) (nextKey []byte, retErr error) {
+	return i.iterateRange(ctx, start, end, limit, fn)
+}
+
+// DeleteRange removes all info records whose key falls in [start, end). A nil
+// end means the range is unbounded above.
+func (i InfoStorage) DeleteRange(ctx context.Context, start, end []byte) error {
+	return i.deleteRange(ctx, start, end)
+}
+
+// BatchGet fetches the latest info record for each of the given infoKeys,
+// issuing a single SELECT ... WHERE info_key = ANY($2) per batch of
+// batchSize keys rather than one round trip per key. Keys with no info
+// record are simply absent from the returned map. batchSize <= 0 uses the
+// default of infoBatchSize.
+func (i InfoStorage) BatchGet(
+	ctx context.Context, infoKeys [][]byte, batchSize int,
+) (map[string][]byte, error) {
+	return i.batchGet(ctx, infoKeys, batchSize)
+}
+
+// BatchWrite writes the provided entries under the same transaction, issuing
+// a single DELETE ... WHERE info_key = ANY($2) followed by a single
+// multi-row INSERT per batch of batchSize entries, rather than one
+// delete-then-insert round trip per entry. batchSize <= 0 uses the default
+// of infoBatchSize.
+func (i InfoStorage) BatchWrite(ctx context.Context, entries []InfoEntry, batchSize int) error {
+	return i.batchWrite(ctx, entries, batchSize)
+}
+
+// BatchDelete removes the info records for each of the given infoKeys under
+// the same transaction, issuing a single DELETE ... WHERE info_key = ANY($2)
+// per batch of batchSize keys rather than one round trip per key. batchSize
+// <= 0 uses the default of infoBatchSize.
+func (i InfoStorage) BatchDelete(ctx context.Context, infoKeys [][]byte, batchSize int) error {
+	return i.batchDelete(ctx, infoKeys, batchSize)
+}
+
 const (
 	legacyPayloadKey  = "legacy_payload"
 	legacyProgressKey = "legacy_progress"
@@ -0,0 +1,252 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jobs_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/jobs"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// setupJobInfoTest starts a server and creates a single job to scope info
+// records to, returning the job and the internal DB to run txns against.
+func setupJobInfoTest(t *testing.T) (*jobs.Job, isql.DB, func()) {
+	ctx := context.Background()
+	s := serverutils.StartServerOnly(t, base.TestServerArgs{})
+
+	db := s.InternalDB().(isql.DB)
+	r := s.JobRegistry().(*jobs.Registry)
+
+	var job *jobs.Job
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		var err error
+		job, err = r.CreateJobWithTxn(ctx, jobs.Record{
+			Details:  jobspb.ImportDetails{},
+			Progress: jobspb.ImportProgress{},
+			Username: username.TestUserName(),
+		}, r.MakeJobID(), txn)
+		return err
+	}))
+
+	return job, db, func() { s.Stopper().Stop(ctx) }
+}
+
+func TestJobInfoStorageBatch(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	job, db, cleanup := setupJobInfoTest(t)
+	defer cleanup()
+
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	entries := []jobs.InfoEntry{
+		{Key: keys[0], Value: []byte("1")},
+		{Key: keys[1], Value: []byte("2")},
+		{Key: keys[2], Value: []byte("3")},
+	}
+
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).BatchWrite(ctx, entries, 0)
+	}))
+
+	var got map[string][]byte
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		var err error
+		got, err = job.InfoStorage(txn).BatchGet(ctx, keys, 0)
+		return err
+	}))
+	require.Equal(t, map[string][]byte{"a": []byte("1"), "b": []byte("2"), "c": []byte("3")}, got)
+
+	// Overwriting via BatchWrite should replace, not duplicate, revisions.
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).BatchWrite(ctx, []jobs.InfoEntry{{Key: keys[0], Value: []byte("1-updated")}}, 0)
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		value, ok, err := job.InfoStorage(txn).Get(ctx, keys[0])
+		require.True(t, ok)
+		require.Equal(t, []byte("1-updated"), value)
+		return err
+	}))
+
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).BatchDelete(ctx, keys[:2], 0)
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		got, err := job.InfoStorage(txn).BatchGet(ctx, keys, 0)
+		require.NoError(t, err)
+		require.Equal(t, map[string][]byte{"c": []byte("3")}, got)
+		return nil
+	}))
+
+	// A caller-supplied batchSize should be honored, chunking the batch into
+	// more than one statement rather than always using the infoBatchSize
+	// default.
+	wideKeys := [][]byte{[]byte("w0"), []byte("w1"), []byte("w2"), []byte("w3"), []byte("w4")}
+	wideEntries := make([]jobs.InfoEntry, len(wideKeys))
+	for idx, k := range wideKeys {
+		wideEntries[idx] = jobs.InfoEntry{Key: k, Value: k}
+	}
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).BatchWrite(ctx, wideEntries, 2)
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		got, err := job.InfoStorage(txn).BatchGet(ctx, wideKeys, 2)
+		require.NoError(t, err)
+		require.Len(t, got, len(wideKeys))
+		return nil
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).BatchDelete(ctx, wideKeys, 2)
+	}))
+}
+
+func TestJobInfoStorageIterateRange(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	job, db, cleanup := setupJobInfoTest(t)
+	defer cleanup()
+
+	const numKeys = 5
+	entries := make([]jobs.InfoEntry, numKeys)
+	for i := 0; i < numKeys; i++ {
+		entries[i] = jobs.InfoEntry{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))}
+	}
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).BatchWrite(ctx, entries, 0)
+	}))
+
+	// Overwrite one key with Write, which replaces its older revision, to make
+	// sure pagination still yields that key exactly once.
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).Write(ctx, []byte("k2"), []byte("v2-updated"))
+	}))
+
+	var seen []string
+	var cursor []byte
+	for {
+		var next []byte
+		require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+			var err error
+			next, err = job.InfoStorage(txn).IterateRange(ctx, cursor, nil, 2, func(k, v []byte) error {
+				seen = append(seen, string(k))
+				return nil
+			})
+			return err
+		}))
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+	require.Equal(t, []string{"k0", "k1", "k2", "k3", "k4"}, seen)
+
+	// DeleteRange removes [k1, k3): k1 and k2 go, k3 itself is kept.
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		return job.InfoStorage(txn).DeleteRange(ctx, []byte("k1"), []byte("k3"))
+	}))
+	// A nil end is unbounded above.
+	var remaining []string
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		_, err := job.InfoStorage(txn).IterateRange(ctx, nil, nil, numKeys, func(k, v []byte) error {
+			remaining = append(remaining, string(k))
+			return nil
+		})
+		return err
+	}))
+	require.Equal(t, []string{"k0", "k3", "k4"}, remaining)
+}
+
+func TestJobInfoStorageCompareAndSwap(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	job, db, cleanup := setupJobInfoTest(t)
+	defer cleanup()
+
+	key := []byte("cas-key")
+
+	// WriteIfAbsent succeeds the first time...
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		swapped, err := job.InfoStorage(txn).WriteIfAbsent(ctx, key, []byte("v1"))
+		require.NoError(t, err)
+		require.True(t, swapped)
+		return nil
+	}))
+
+	// ...and fails once the key exists.
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		swapped, err := job.InfoStorage(txn).WriteIfAbsent(ctx, key, []byte("v2"))
+		require.NoError(t, err)
+		require.False(t, swapped)
+		return nil
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		value, ok, err := job.InfoStorage(txn).Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, []byte("v1"), value)
+		return nil
+	}))
+
+	// CompareAndSwap with the wrong expected value is a no-op.
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		swapped, err := job.InfoStorage(txn).CompareAndSwap(ctx, key, []byte("not-v1"), []byte("v2"))
+		require.NoError(t, err)
+		require.False(t, swapped)
+		return nil
+	}))
+
+	// CompareAndSwap with the right expected value succeeds.
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		swapped, err := job.InfoStorage(txn).CompareAndSwap(ctx, key, []byte("v1"), []byte("v2"))
+		require.NoError(t, err)
+		require.True(t, swapped)
+		return nil
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		value, ok, err := job.InfoStorage(txn).Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, bytes.Equal([]byte("v2"), value))
+		return nil
+	}))
+
+	// An empty stored value is not the same as an absent key: WriteIfAbsent
+	// must not swap over it.
+	emptyKey := []byte("cas-empty-key")
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		swapped, err := job.InfoStorage(txn).WriteIfAbsent(ctx, emptyKey, []byte{})
+		require.NoError(t, err)
+		require.True(t, swapped)
+		return nil
+	}))
+	require.NoError(t, db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		swapped, err := job.InfoStorage(txn).WriteIfAbsent(ctx, emptyKey, []byte("should-not-land"))
+		require.NoError(t, err)
+		require.False(t, swapped)
+		return nil
+	}))
+}